@@ -1,4 +1,4 @@
-// Package ftracker реализует функции для подсчета затраченных калориев для разных видов активностей.
+// Package ftracker реализует функции для подсчета затраченных калорий для разных видов активностей.
 package ftracker
 
 import (
@@ -38,52 +38,222 @@ func MeanSpeed(action int, duration float64) float64 {
 	return distance / duration
 }
 
-// ShowTrainInfo возвращает строку с информацией о тренировке.
+// Training описывает тренировку, для которой можно рассчитать дистанцию, среднюю скорость и
+// затраченные калории, а также получить готовую для показа пользователю строку с информацией.
+// Тренировки, не привязанные к перемещению в пространстве (силовые, с собственным весом),
+// реализуют интерфейс, но возвращают 0 для Distance и MeanSpeed.
+type Training interface {
+	// Distance возвращает дистанцию в километрах, пройденную за тренировку.
+	Distance() float64
+	// MeanSpeed возвращает среднюю скорость движения во время тренировки в км/ч.
+	MeanSpeed() float64
+	// Calories возвращает количество калорий, затраченных за тренировку.
+	Calories() float64
+	// Info возвращает строку с информацией о тренировке.
+	Info() string
+}
+
+// ShowTrainInfo возвращает строку с информацией о тренировке. Конкретное наполнение строки
+// зависит от вида тренировки и формируется методом Info переданного значения.
 //
 // Параметры:
 //
-// action int — количество совершенных действий(число шагов при ходьбе и беге, либо гребков при плавании).
-// trainingType string — вид тренировки(Бег, Ходьба, Плавание).
-// duration float64 — длительность тренировки в часах.
-func ShowTrainInfo(
-	action int,
-	trainingType string,
-	duration, weight, height float64,
-	lengthPool, countPool int) string {
-	switch {
-	case trainingType == "Бег":
-		distance := Distance(action)
-		speed := MeanSpeed(action, duration)
-		calories := RunSpentCal(action, weight, duration)
-		return fmt.Sprintf(`Тип тренировки: %s
+// training Training — тренировка, информацию о которой нужно показать.
+func ShowTrainInfo(training Training) string {
+	return training.Info()
+}
+
+// RunningTraining описывает тренировку типа "Бег".
+type RunningTraining struct {
+	Action   int     // количество шагов.
+	Duration float64 // длительность тренировки в часах.
+	Weight   float64 // вес пользователя.
+}
+
+// Distance возвращает дистанцию, пройденную во время пробежки.
+func (r RunningTraining) Distance() float64 {
+	return Distance(r.Action)
+}
+
+// MeanSpeed возвращает среднюю скорость во время пробежки.
+func (r RunningTraining) MeanSpeed() float64 {
+	return MeanSpeed(r.Action, r.Duration)
+}
+
+// Calories возвращает количество калорий, затраченных во время пробежки.
+func (r RunningTraining) Calories() float64 {
+	return RunSpentCal(r.Action, r.Weight, r.Duration)
+}
+
+// Info возвращает строку с информацией о пробежке.
+func (r RunningTraining) Info() string {
+	return fmt.Sprintf(`Тип тренировки: Бег
 Длительность: %.2f ч.
 Дистанция: %.2f км.
 Скорость: %.2f км/ч
 Сожгли калорий: %.2f
-`, trainingType, duration, distance, speed, calories)
-	case trainingType == "Ходьба":
-		distance := Distance(action)
-		speed := MeanSpeed(action, duration)
-		calories := WalkingSpentCalories(action, duration, weight, height)
-		return fmt.Sprintf(`Тип тренировки: %s
+`, r.Duration, r.Distance(), r.MeanSpeed(), r.Calories())
+}
+
+// WalkingTraining описывает тренировку типа "Ходьба".
+type WalkingTraining struct {
+	Action   int     // количество шагов.
+	Duration float64 // длительность тренировки в часах.
+	Weight   float64 // вес пользователя.
+	Height   float64 // рост пользователя.
+}
+
+// Distance возвращает дистанцию, пройденную во время ходьбы.
+func (w WalkingTraining) Distance() float64 {
+	return Distance(w.Action)
+}
+
+// MeanSpeed возвращает среднюю скорость во время ходьбы.
+func (w WalkingTraining) MeanSpeed() float64 {
+	return MeanSpeed(w.Action, w.Duration)
+}
+
+// Calories возвращает количество калорий, затраченных во время ходьбы.
+func (w WalkingTraining) Calories() float64 {
+	return WalkingSpentCalories(w.Action, w.Duration, w.Weight, w.Height)
+}
+
+// Info возвращает строку с информацией о ходьбе.
+func (w WalkingTraining) Info() string {
+	return fmt.Sprintf(`Тип тренировки: Ходьба
 Длительность: %.2f ч.
 Дистанция: %.2f км.
 Скорость: %.2f км/ч
 Сожгли калорий: %.2f
-`, trainingType, duration, distance, speed, calories)
-	case trainingType == "Плавание":
-		distance := Distance(action)
-		speed := SwimmingMeanSpeed(lengthPool, countPool, duration)
-		calories := SwimSpentCal(lengthPool, countPool, duration, weight)
-		return fmt.Sprintf(`Тип тренировки: %s
+`, w.Duration, w.Distance(), w.MeanSpeed(), w.Calories())
+}
+
+// SwimmingTraining описывает тренировку типа "Плавание".
+type SwimmingTraining struct {
+	Action     int     // количество гребков.
+	Duration   float64 // длительность тренировки в часах.
+	Weight     float64 // вес пользователя.
+	LengthPool int     // длина бассейна в метрах.
+	CountPool  int     // сколько раз пользователь переплыл бассейн.
+}
+
+// Distance возвращает дистанцию, пройденную во время плавания.
+func (s SwimmingTraining) Distance() float64 {
+	return Distance(s.Action)
+}
+
+// MeanSpeed возвращает среднюю скорость во время плавания.
+func (s SwimmingTraining) MeanSpeed() float64 {
+	return SwimmingMeanSpeed(s.LengthPool, s.CountPool, s.Duration)
+}
+
+// Calories возвращает количество калорий, затраченных во время плавания.
+func (s SwimmingTraining) Calories() float64 {
+	return SwimSpentCal(s.LengthPool, s.CountPool, s.Duration, s.Weight)
+}
+
+// Info возвращает строку с информацией о плавании.
+func (s SwimmingTraining) Info() string {
+	return fmt.Sprintf(`Тип тренировки: Плавание
 Длительность: %.2f ч.
 Дистанция: %.2f км.
 Скорость: %.2f км/ч
 Сожгли калорий: %.2f
-`, trainingType, duration, distance, speed, calories)
-	default:
-		return "неизвестный тип тренировки"
-	}
+`, s.Duration, s.Distance(), s.MeanSpeed(), s.Calories())
+}
+
+// Константы для расчета калорий, расходуемых при силовой тренировке.
+const (
+	StrengthTrainingMET = 6.0 // среднее значение MET для силовой тренировки (работа со свободными весами).
+)
+
+// StrengthTraining описывает силовую тренировку: жим, тягу, приседания со штангой и прочие
+// упражнения с отягощением. Такая тренировка не связана с перемещением в пространстве, поэтому
+// Distance и MeanSpeed всегда возвращают 0, а вместо них Info показывает тоннаж тренировки.
+type StrengthTraining struct {
+	WeightLifted float64 // вес снаряда в кг.
+	Reps         int     // количество повторений в подходе.
+	Sets         int     // количество подходов.
+	Duration     float64 // длительность тренировки в часах.
+	Weight       float64 // вес пользователя.
+}
+
+// Distance для силовой тренировки не определена и всегда возвращает 0.
+func (s StrengthTraining) Distance() float64 {
+	return 0
+}
+
+// MeanSpeed для силовой тренировки не определена и всегда возвращает 0.
+func (s StrengthTraining) MeanSpeed() float64 {
+	return 0
+}
+
+// Volume возвращает суммарный тоннаж тренировки — вес снаряда, умноженный на количество
+// повторений и подходов.
+func (s StrengthTraining) Volume() float64 {
+	return s.WeightLifted * float64(s.Reps) * float64(s.Sets)
+}
+
+// Calories возвращает количество калорий, затраченных во время силовой тренировки, по
+// MET-формуле: затраты зависят от веса пользователя и длительности тренировки.
+func (s StrengthTraining) Calories() float64 {
+	return StrengthTrainingMET * s.Weight * s.Duration
+}
+
+// Info возвращает строку с информацией о силовой тренировке.
+func (s StrengthTraining) Info() string {
+	return fmt.Sprintf(`Тип тренировки: Силовая тренировка
+Длительность: %.2f ч.
+Подходы: %d
+Повторения: %d
+Тоннаж: %.2f кг.
+Сожгли калорий: %.2f
+`, s.Duration, s.Sets, s.Reps, s.Volume(), s.Calories())
+}
+
+// Константы MET-коэффициентов для тренировок с собственным весом. Значения приведены для
+// типичных представителей каждой категории и могут переопределяться через поле MET.
+const (
+	RepetitionTrainingMETYoga       = 3.0 // MET для йоги и растяжки.
+	RepetitionTrainingMETBodyweight = 5.0 // MET для отжиманий, приседаний и прочих упражнений с собственным весом.
+	RepetitionTrainingMETHIIT       = 8.0 // MET для высокоинтенсивных интервальных тренировок (HIIT).
+)
+
+// RepetitionTraining описывает тренировку с собственным весом: отжимания, приседания, йогу,
+// HIIT-круги и прочие активности без снарядов и без перемещения в пространстве, поэтому
+// Distance и MeanSpeed всегда возвращают 0.
+type RepetitionTraining struct {
+	Reps     int     // количество повторений.
+	Sets     int     // количество подходов.
+	Duration float64 // длительность тренировки в часах.
+	Weight   float64 // вес пользователя.
+	MET      float64 // MET-коэффициент конкретной активности (см. RepetitionTrainingMET*).
+}
+
+// Distance для тренировки с собственным весом не определена и всегда возвращает 0.
+func (r RepetitionTraining) Distance() float64 {
+	return 0
+}
+
+// MeanSpeed для тренировки с собственным весом не определена и всегда возвращает 0.
+func (r RepetitionTraining) MeanSpeed() float64 {
+	return 0
+}
+
+// Calories возвращает количество калорий, затраченных во время тренировки, по MET-формуле:
+// MET-коэффициент активности, умноженный на вес пользователя и длительность тренировки.
+func (r RepetitionTraining) Calories() float64 {
+	return r.MET * r.Weight * r.Duration
+}
+
+// Info возвращает строку с информацией о тренировке с собственным весом.
+func (r RepetitionTraining) Info() string {
+	return fmt.Sprintf(`Тип тренировки: Тренировка с собственным весом
+Длительность: %.2f ч.
+Подходы: %d
+Повторения: %d
+Сожгли калорий: %.2f
+`, r.Duration, r.Sets, r.Reps, r.Calories())
 }
 
 // Константы для расчета калорий, расходуемых при беге.
@@ -0,0 +1,458 @@
+// Package fitparse читает файлы экспорта с фитнес-устройств (Garmin FIT и GPX) и строит по ним
+// тренировки пакета ftracker, пригодные для дальнейшего расчета дистанции, скорости и калорий
+// через ShowTrainInfo.
+package fitparse
+
+import (
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/HikLk/go-sprint-four"
+)
+
+// Sport обозначает вид активности, извлеченный из файла устройства.
+type Sport string
+
+// Поддерживаемые виды активности.
+const (
+	SportRunning  Sport = "Бег"
+	SportWalking  Sport = "Ходьба"
+	SportSwimming Sport = "Плавание"
+)
+
+// FormatMinSec переводит длительность в секундах в строку вида "мм:сс".
+func FormatMinSec(seconds float64) string {
+	total := int(math.Round(seconds))
+	minutes := total / 60
+	secs := total % 60
+	return fmt.Sprintf("%02d:%02d", minutes, secs)
+}
+
+// Pace возвращает темп в формате "мм:сс" на километр для заданной дистанции (в км) и
+// длительности (в часах). Для нулевой дистанции возвращает "00:00".
+func Pace(distanceKm, duration float64) string {
+	if distanceKm == 0 {
+		return "00:00"
+	}
+	secPerKm := duration * 3600 / distanceKm
+	return FormatMinSec(secPerKm)
+}
+
+// Lap описывает один круг/сессию, извлеченную из файла устройства, до превращения в
+// ftracker.Training. Action — число шагов (бег, ходьба) или гребков (плавание), Duration —
+// длительность круга в часах.
+type Lap struct {
+	Sport      Sport
+	Action     int
+	Duration   float64
+	LengthPool int // заполняется только для плавания.
+	CountPool  int // заполняется только для плавания.
+}
+
+// ToTraining превращает круг в тренировку пакета ftracker с учетом веса и роста пользователя,
+// которые устройство не записывает.
+func (l Lap) ToTraining(weight, height float64) ftracker.Training {
+	switch l.Sport {
+	case SportWalking:
+		return ftracker.WalkingTraining{Action: l.Action, Duration: l.Duration, Weight: weight, Height: height}
+	case SportSwimming:
+		return ftracker.SwimmingTraining{
+			Action:     l.Action,
+			Duration:   l.Duration,
+			Weight:     weight,
+			LengthPool: l.LengthPool,
+			CountPool:  l.CountPool,
+		}
+	default:
+		return ftracker.RunningTraining{Action: l.Action, Duration: l.Duration, Weight: weight}
+	}
+}
+
+// earthRadiusKm — средний радиус Земли, используется для расчета дистанции по координатам.
+const earthRadiusKm = 6371.0
+
+// haversineKm возвращает расстояние в километрах между двумя точками на сфере Земли.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return earthRadiusKm * c
+}
+
+type gpxFile struct {
+	XMLName xml.Name   `xml:"gpx"`
+	Tracks  []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Type     string       `xml:"type"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Time string  `xml:"time"`
+}
+
+// sportFromGPXType сопоставляет значение <type> трека GPX с видом активности пакета ftracker.
+// Если значение не распознано, используется defaultSport.
+func sportFromGPXType(t string, defaultSport Sport) Sport {
+	switch t {
+	case "running", "run":
+		return SportRunning
+	case "walking", "walk", "hiking":
+		return SportWalking
+	case "swimming", "swim":
+		return SportSwimming
+	default:
+		return defaultSport
+	}
+}
+
+// ParseGPX читает GPX-файл, извлекает по одному кругу на каждый <trkseg> и возвращает готовые
+// тренировки пакета ftracker. Так как GPX не содержит вес и рост пользователя, их нужно
+// передать явно, а defaultSport используется, когда вид активности не указан в файле.
+func ParseGPX(path string, defaultSport Sport, weight, height float64) ([]ftracker.Training, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fitparse: чтение GPX-файла: %w", err)
+	}
+
+	var parsed gpxFile
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("fitparse: разбор GPX-файла: %w", err)
+	}
+
+	var trainings []ftracker.Training
+	for _, track := range parsed.Tracks {
+		sport := sportFromGPXType(track.Type, defaultSport)
+		for _, seg := range track.Segments {
+			lap, err := lapFromGPXSegment(seg, sport)
+			if err != nil {
+				return nil, err
+			}
+			if lap == nil {
+				continue
+			}
+			trainings = append(trainings, lap.ToTraining(weight, height))
+		}
+	}
+	return trainings, nil
+}
+
+// lapFromGPXSegment суммирует дистанцию по точкам сегмента и определяет его длительность по
+// первой и последней временной метке. Возвращает nil, если в сегменте меньше двух точек.
+func lapFromGPXSegment(seg gpxSegment, sport Sport) (*Lap, error) {
+	if len(seg.Points) < 2 {
+		return nil, nil
+	}
+
+	var distanceKm float64
+	for i := 1; i < len(seg.Points); i++ {
+		prev, cur := seg.Points[i-1], seg.Points[i]
+		distanceKm += haversineKm(prev.Lat, prev.Lon, cur.Lat, cur.Lon)
+	}
+
+	start, err := time.Parse(time.RFC3339, seg.Points[0].Time)
+	if err != nil {
+		return nil, fmt.Errorf("fitparse: время начала сегмента: %w", err)
+	}
+	end, err := time.Parse(time.RFC3339, seg.Points[len(seg.Points)-1].Time)
+	if err != nil {
+		return nil, fmt.Errorf("fitparse: время окончания сегмента: %w", err)
+	}
+	duration := end.Sub(start).Hours()
+
+	action := actionFromDistance(distanceKm, sport)
+	return &Lap{Sport: sport, Action: action, Duration: duration}, nil
+}
+
+// actionFromDistance переводит дистанцию в километрах в число действий (шагов или гребков),
+// используя среднюю длину шага ftracker.LenStep.
+func actionFromDistance(distanceKm float64, sport Sport) int {
+	return int(math.Round(distanceKm * MInKm / ftracker.LenStep))
+}
+
+// MInKm — количество метров в километре, взятое из ftracker.MInKm.
+const MInKm = ftracker.MInKm
+
+// Глобальные номера FIT-сообщений, которые разбирает парсер (см. профиль Garmin FIT SDK).
+const (
+	fitMesgNumLap     = 19
+	fitMesgNumSession = 18
+)
+
+// Номера полей сообщения Lap, которые нужны для расчета калорий.
+const (
+	fitFieldTotalElapsedTime = 7  // uint32, масштаб 1000, секунды.
+	fitFieldTotalDistance    = 9  // uint32, масштаб 100, метры.
+	fitFieldTotalCycles      = 10 // uint32, шаги (бег/ходьба) или гребки*2 (плавание).
+	fitFieldSport            = 25 // enum: см. fitSportRunning и соседние константы.
+)
+
+// fitFieldPoolLength — номер поля pool_length в сообщении Session: длина бассейна, uint16,
+// масштаб 100, метры. Используется, чтобы не гадать о длине бассейна при разборе кругов плавания.
+const fitFieldPoolLength = 44
+
+// fitSportRunning и соседние константы — значения перечисления sport из профиля FIT SDK.
+const (
+	fitSportRunning  = 1
+	fitSportCycling  = 2
+	fitSportWalking  = 11
+	fitSportSwimming = 5
+)
+
+// sportFromFIT сопоставляет значение sport из FIT с видом активности пакета ftracker. Второе
+// значение равно false, если вид активности не поддерживается.
+func sportFromFIT(value uint8) (Sport, bool) {
+	switch value {
+	case fitSportRunning:
+		return SportRunning, true
+	case fitSportWalking:
+		return SportWalking, true
+	case fitSportSwimming:
+		return SportSwimming, true
+	default:
+		return "", false
+	}
+}
+
+// fitFieldDef описывает одно поле из сообщения-определения FIT.
+type fitFieldDef struct {
+	num  byte
+	size byte
+}
+
+// fitDefinition описывает локальный тип сообщения FIT: к какому глобальному сообщению он
+// относится и как читать его поля.
+type fitDefinition struct {
+	globalMesgNum uint16
+	littleEndian  bool
+	fields        []fitFieldDef
+}
+
+// ParseFIT читает бинарный файл Garmin FIT и возвращает тренировки по каждому найденному кругу
+// (сообщению Lap). Поддерживаются круги бега, ходьбы и плавания; другие виды активности
+// пропускаются. Вес и рост пользователя FIT-файл не содержит, поэтому их нужно передать явно.
+func ParseFIT(path string, weight, height float64) ([]ftracker.Training, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fitparse: чтение FIT-файла: %w", err)
+	}
+
+	headerSize := 0
+	if len(data) > 0 {
+		headerSize = int(data[0])
+	}
+	if headerSize == 0 || len(data) < headerSize+2 {
+		return nil, fmt.Errorf("fitparse: некорректный заголовок FIT-файла")
+	}
+
+	body := data[headerSize : len(data)-2] // последние 2 байта — CRC всего файла.
+
+	// Длина бассейна приходит в сообщении Session, которое обычно идет в файле после всех
+	// кругов, поэтому сначала находим ее отдельным проходом, а уже потом разбираем круги.
+	var poolLengthM float64
+	if err := decodeFITMessages(body, func(def fitDefinition, values map[byte]uint64) {
+		if def.globalMesgNum != fitMesgNumSession {
+			return
+		}
+		if raw, ok := values[fitFieldPoolLength]; ok && raw > 0 {
+			poolLengthM = float64(raw) / 100
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	var laps []Lap
+	if err := decodeFITMessages(body, func(def fitDefinition, values map[byte]uint64) {
+		if def.globalMesgNum != fitMesgNumLap {
+			return
+		}
+		if lap, ok := lapFromFITFields(values, poolLengthM); ok {
+			laps = append(laps, lap)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	trainings := make([]ftracker.Training, 0, len(laps))
+	for _, lap := range laps {
+		trainings = append(trainings, lap.ToTraining(weight, height))
+	}
+	return trainings, nil
+}
+
+// decodeFITMessages проходит по телу FIT-файла и для каждого сообщения данных вызывает onMessage
+// с его определением и прочитанными полями. Помимо обычного заголовка поддерживает сжатый
+// заголовок метки времени (бит 0x80) — формат, которым большинство устройств Garmin кодируют
+// сообщения по умолчанию: в нем тип локального сообщения лежит в битах 5-6, а сообщение всегда
+// является сообщением данных, ссылающимся на уже встреченное определение.
+func decodeFITMessages(body []byte, onMessage func(def fitDefinition, values map[byte]uint64)) error {
+	defs := make(map[byte]fitDefinition)
+	offset := 0
+	for offset < len(body) {
+		header := body[offset]
+		offset++
+
+		if header&0x80 != 0 {
+			localType := (header >> 5) & 0x03
+			def, ok := defs[localType]
+			if !ok {
+				return fmt.Errorf("fitparse: сжатый заголовок ссылается на неизвестное локальное сообщение %d", localType)
+			}
+			values, consumed := readFITFields(body[offset:], def)
+			offset += consumed
+			onMessage(def, values)
+			continue
+		}
+
+		localType := header & 0x0F
+
+		if header&0x40 != 0 {
+			def, consumed, err := parseFITDefinition(body[offset:])
+			if err != nil {
+				return err
+			}
+			defs[localType] = def
+			offset += consumed
+			continue
+		}
+
+		def, ok := defs[localType]
+		if !ok {
+			return fmt.Errorf("fitparse: данные для неизвестного локального сообщения %d", localType)
+		}
+
+		values, consumed := readFITFields(body[offset:], def)
+		offset += consumed
+		onMessage(def, values)
+	}
+	return nil
+}
+
+// parseFITDefinition разбирает сообщение-определение и возвращает, сколько байт оно заняло.
+func parseFITDefinition(buf []byte) (fitDefinition, int, error) {
+	if len(buf) < 5 {
+		return fitDefinition{}, 0, fmt.Errorf("fitparse: усеченное сообщение-определение")
+	}
+	littleEndian := buf[1] == 0
+	globalMesgNum := binary.LittleEndian.Uint16(buf[2:4])
+	if !littleEndian {
+		globalMesgNum = binary.BigEndian.Uint16(buf[2:4])
+	}
+	numFields := int(buf[4])
+
+	offset := 5
+	fields := make([]fitFieldDef, 0, numFields)
+	for i := 0; i < numFields; i++ {
+		if offset+3 > len(buf) {
+			return fitDefinition{}, 0, fmt.Errorf("fitparse: усеченное описание поля")
+		}
+		fields = append(fields, fitFieldDef{num: buf[offset], size: buf[offset+1]})
+		offset += 3
+	}
+
+	return fitDefinition{globalMesgNum: globalMesgNum, littleEndian: littleEndian, fields: fields}, offset, nil
+}
+
+// readFITFields читает значения полей сообщения данных согласно его определению и возвращает
+// их вместе с числом прочитанных байт.
+func readFITFields(buf []byte, def fitDefinition) (map[byte]uint64, int) {
+	values := make(map[byte]uint64, len(def.fields))
+	offset := 0
+	for _, field := range def.fields {
+		end := offset + int(field.size)
+		if end > len(buf) {
+			break
+		}
+		values[field.num] = readFITUint(buf[offset:end], def.littleEndian)
+		offset = end
+	}
+	return values, offset
+}
+
+// readFITUint читает беззнаковое целое произвольной ширины (1, 2 или 4 байта) с учетом порядка
+// байт сообщения.
+func readFITUint(raw []byte, littleEndian bool) uint64 {
+	switch len(raw) {
+	case 1:
+		return uint64(raw[0])
+	case 2:
+		if littleEndian {
+			return uint64(binary.LittleEndian.Uint16(raw))
+		}
+		return uint64(binary.BigEndian.Uint16(raw))
+	case 4:
+		if littleEndian {
+			return uint64(binary.LittleEndian.Uint32(raw))
+		}
+		return uint64(binary.BigEndian.Uint32(raw))
+	default:
+		var v uint64
+		for i, b := range raw {
+			if littleEndian {
+				v |= uint64(b) << (8 * i)
+			} else {
+				v = v<<8 | uint64(b)
+			}
+		}
+		return v
+	}
+}
+
+// lapFromFITFields превращает набор прочитанных полей сообщения Lap в Lap пакета fitparse.
+// poolLengthM — длина бассейна в метрах, извлеченная из сообщения Session, или 0, если файл ее
+// не содержит.
+func lapFromFITFields(values map[byte]uint64, poolLengthM float64) (Lap, bool) {
+	elapsed, ok := values[fitFieldTotalElapsedTime]
+	if !ok {
+		return Lap{}, false
+	}
+	duration := float64(elapsed) / 1000 / 3600 // секунды с масштабом 1000 -> часы.
+
+	sport, ok := sportFromFIT(uint8(values[fitFieldSport]))
+	if !ok {
+		return Lap{}, false
+	}
+
+	var distanceKm float64
+	if dist, ok := values[fitFieldTotalDistance]; ok {
+		distanceKm = float64(dist) / 100 / MInKm
+	}
+
+	var action int
+	if cycles, ok := values[fitFieldTotalCycles]; ok && cycles > 0 {
+		if sport == SportSwimming {
+			// total_cycles считает гребки обеими руками (цикл = 2 гребка), а Action для
+			// плавания — число гребков.
+			action = int(cycles / 2)
+		} else {
+			action = int(cycles)
+		}
+	} else {
+		action = actionFromDistance(distanceKm, sport)
+	}
+
+	lap := Lap{Sport: sport, Action: action, Duration: duration}
+	if sport == SportSwimming && poolLengthM > 0 {
+		lap.LengthPool = int(math.Round(poolLengthM))
+		lap.CountPool = int(math.Round(distanceKm * MInKm / poolLengthM))
+	}
+	// Если длина бассейна неизвестна (в файле нет сообщения Session с полем pool_length),
+	// LengthPool и CountPool остаются нулевыми: мы не гадаем о длине бассейна, это известное
+	// ограничение парсера для таких файлов.
+	return lap, true
+}
@@ -0,0 +1,252 @@
+package fitparse
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HikLk/go-sprint-four"
+)
+
+// fitHeaderSize — длина тестового заголовка FIT-файла; реальное содержимое заголовка, кроме
+// первого байта (его размера), парсером не используется.
+const fitHeaderSize = 12
+
+// fitDefMessage собирает байты сообщения-определения: заголовок с выставленным битом 0x40,
+// номер глобального сообщения и список полей (номер, размер).
+func fitDefMessage(localType byte, globalMesgNum uint16, fields [][2]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(0x40 | localType)
+	buf.WriteByte(0) // reserved.
+	buf.WriteByte(0) // architecture: 0 = little-endian.
+	binary.Write(&buf, binary.LittleEndian, globalMesgNum)
+	buf.WriteByte(byte(len(fields)))
+	for _, f := range fields {
+		buf.WriteByte(f[0]) // номер поля.
+		buf.WriteByte(f[1]) // размер поля в байтах.
+		buf.WriteByte(0)    // базовый тип, парсером не используется.
+	}
+	return buf.Bytes()
+}
+
+// fitDataMessage собирает байты обычного сообщения данных для заданного локального типа.
+func fitDataMessage(localType byte, fieldBytes ...[]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(localType)
+	for _, fb := range fieldBytes {
+		buf.Write(fb)
+	}
+	return buf.Bytes()
+}
+
+// fitCompressedDataMessage собирает байты сообщения данных со сжатым заголовком метки времени
+// (бит 0x80), ссылающегося на localType, уже объявленный предыдущим сообщением-определением.
+func fitCompressedDataMessage(localType byte, timeOffset byte, fieldBytes ...[]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(0x80 | (localType << 5) | (timeOffset & 0x1F))
+	for _, fb := range fieldBytes {
+		buf.Write(fb)
+	}
+	return buf.Bytes()
+}
+
+func u32le(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
+
+func u16le(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, v)
+	return b
+}
+
+// writeFITFile оборачивает тело сообщений в минимальный заголовок и завершающий CRC (не
+// проверяется парсером) и записывает получившийся файл во временную директорию теста.
+func writeFITFile(t *testing.T, body []byte) string {
+	t.Helper()
+	header := make([]byte, fitHeaderSize)
+	header[0] = fitHeaderSize
+	data := append(header, body...)
+	data = append(data, 0, 0) // CRC, значение не проверяется.
+
+	path := filepath.Join(t.TempDir(), "activity.fit")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("запись тестового FIT-файла: %v", err)
+	}
+	return path
+}
+
+var lapFields = [][2]byte{
+	{fitFieldTotalElapsedTime, 4},
+	{fitFieldTotalDistance, 4},
+	{fitFieldTotalCycles, 4},
+	{fitFieldSport, 1},
+}
+
+func lapData(localType byte, elapsedMs, distanceCm, cycles uint32, sport byte) []byte {
+	return fitDataMessage(localType, u32le(elapsedMs), u32le(distanceCm), u32le(cycles), []byte{sport})
+}
+
+func TestParseFIT_NormalAndCompressedHeader(t *testing.T) {
+	var body bytes.Buffer
+	body.Write(fitDefMessage(0, fitMesgNumLap, lapFields))
+	body.Write(lapData(0, 3_600_000, 500_000, 6000, fitSportRunning))
+	body.Write(fitCompressedDataMessage(0, 5, lapData(0, 1_800_000, 250_000, 3000, fitSportRunning)[1:]))
+
+	path := writeFITFile(t, body.Bytes())
+
+	trainings, err := ParseFIT(path, 70, 180)
+	if err != nil {
+		t.Fatalf("ParseFIT вернул ошибку: %v", err)
+	}
+	if len(trainings) != 2 {
+		t.Fatalf("ожидали 2 тренировки (обычный + сжатый заголовок), получили %d", len(trainings))
+	}
+
+	run, ok := trainings[0].(ftracker.RunningTraining)
+	if !ok {
+		t.Fatalf("первая тренировка не RunningTraining: %T", trainings[0])
+	}
+	if run.Action != 6000 {
+		t.Errorf("Action = %d, хотим 6000", run.Action)
+	}
+	if run.Duration != 1.0 {
+		t.Errorf("Duration = %v, хотим 1.0", run.Duration)
+	}
+
+	compressed, ok := trainings[1].(ftracker.RunningTraining)
+	if !ok {
+		t.Fatalf("вторая тренировка (сжатый заголовок) не RunningTraining: %T", trainings[1])
+	}
+	if compressed.Action != 3000 {
+		t.Errorf("Action (сжатый заголовок) = %d, хотим 3000", compressed.Action)
+	}
+	if compressed.Duration != 0.5 {
+		t.Errorf("Duration (сжатый заголовок) = %v, хотим 0.5", compressed.Duration)
+	}
+}
+
+func TestParseFIT_SwimPoolLengthFromSession(t *testing.T) {
+	sessionFields := [][2]byte{{fitFieldPoolLength, 2}}
+
+	var body bytes.Buffer
+	body.Write(fitDefMessage(1, fitMesgNumSession, sessionFields))
+	body.Write(fitDataMessage(1, u16le(2500))) // длина бассейна 25 м, масштаб 100.
+	body.Write(fitDefMessage(0, fitMesgNumLap, lapFields))
+	body.Write(lapData(0, 1_800_000, 100_000, 0, fitSportSwimming)) // 1 км за полчаса.
+
+	path := writeFITFile(t, body.Bytes())
+
+	trainings, err := ParseFIT(path, 70, 180)
+	if err != nil {
+		t.Fatalf("ParseFIT вернул ошибку: %v", err)
+	}
+	if len(trainings) != 1 {
+		t.Fatalf("ожидали 1 тренировку, получили %d", len(trainings))
+	}
+
+	swim, ok := trainings[0].(ftracker.SwimmingTraining)
+	if !ok {
+		t.Fatalf("тренировка не SwimmingTraining: %T", trainings[0])
+	}
+	if swim.LengthPool != 25 {
+		t.Errorf("LengthPool = %d, хотим 25 (из сообщения Session)", swim.LengthPool)
+	}
+	if swim.CountPool != 40 {
+		t.Errorf("CountPool = %d, хотим 40 (1000 м / 25 м)", swim.CountPool)
+	}
+}
+
+func TestParseFIT_SwimWithoutSessionLeavesPoolUnset(t *testing.T) {
+	var body bytes.Buffer
+	body.Write(fitDefMessage(0, fitMesgNumLap, lapFields))
+	body.Write(lapData(0, 1_800_000, 100_000, 0, fitSportSwimming))
+
+	path := writeFITFile(t, body.Bytes())
+
+	trainings, err := ParseFIT(path, 70, 180)
+	if err != nil {
+		t.Fatalf("ParseFIT вернул ошибку: %v", err)
+	}
+	swim, ok := trainings[0].(ftracker.SwimmingTraining)
+	if !ok {
+		t.Fatalf("тренировка не SwimmingTraining: %T", trainings[0])
+	}
+	if swim.LengthPool != 0 || swim.CountPool != 0 {
+		t.Errorf("без сообщения Session парсер не должен угадывать бассейн, получили LengthPool=%d CountPool=%d", swim.LengthPool, swim.CountPool)
+	}
+}
+
+func TestParseFIT_SwimActionHalvesTotalCycles(t *testing.T) {
+	var body bytes.Buffer
+	body.Write(fitDefMessage(0, fitMesgNumLap, lapFields))
+	body.Write(lapData(0, 1_800_000, 100_000, 80, fitSportSwimming)) // total_cycles = 80 гребков обеими руками.
+
+	path := writeFITFile(t, body.Bytes())
+
+	trainings, err := ParseFIT(path, 70, 180)
+	if err != nil {
+		t.Fatalf("ParseFIT вернул ошибку: %v", err)
+	}
+	swim, ok := trainings[0].(ftracker.SwimmingTraining)
+	if !ok {
+		t.Fatalf("тренировка не SwimmingTraining: %T", trainings[0])
+	}
+	if swim.Action != 40 {
+		t.Errorf("Action = %d, хотим 40 (total_cycles=80 / 2 гребка на цикл)", swim.Action)
+	}
+}
+
+func TestParseFIT_UnknownLocalMessageErrors(t *testing.T) {
+	body := fitDataMessage(0, u32le(0)) // данные без предшествующего определения.
+	path := writeFITFile(t, body)
+
+	if _, err := ParseFIT(path, 70, 180); err == nil {
+		t.Fatal("ожидали ошибку для данных неизвестного локального сообщения, получили nil")
+	}
+}
+
+const gpxSample = `<?xml version="1.0"?>
+<gpx>
+  <trk>
+    <type>running</type>
+    <trkseg>
+      <trkpt lat="55.7558" lon="37.6173">
+        <time>2026-07-27T08:00:00Z</time>
+      </trkpt>
+      <trkpt lat="55.7658" lon="37.6173">
+        <time>2026-07-27T08:30:00Z</time>
+      </trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+func TestParseGPX(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "activity.gpx")
+	if err := os.WriteFile(path, []byte(gpxSample), 0o644); err != nil {
+		t.Fatalf("запись тестового GPX-файла: %v", err)
+	}
+
+	trainings, err := ParseGPX(path, SportWalking, 70, 180)
+	if err != nil {
+		t.Fatalf("ParseGPX вернул ошибку: %v", err)
+	}
+	if len(trainings) != 1 {
+		t.Fatalf("ожидали 1 тренировку, получили %d", len(trainings))
+	}
+
+	run, ok := trainings[0].(ftracker.RunningTraining)
+	if !ok {
+		t.Fatalf("вид активности должен браться из <type>running</type>, получили %T", trainings[0])
+	}
+	if run.Duration != 0.5 {
+		t.Errorf("Duration = %v, хотим 0.5 (30 минут)", run.Duration)
+	}
+	if run.Action <= 0 {
+		t.Errorf("Action = %d, хотим положительное число шагов по пройденной дистанции", run.Action)
+	}
+}
@@ -0,0 +1,161 @@
+package ftracker
+
+import (
+	"fmt"
+	"time"
+)
+
+// HRSample — одно показание пульса в конкретный момент тренировки.
+type HRSample struct {
+	Time time.Time // момент замера.
+	BPM  float64   // пульс, ударов в минуту.
+}
+
+// Zone описывает один из пяти диапазонов интенсивности тренировки по пульсу.
+type Zone struct {
+	Name   string  // название зоны (Z1...Z5).
+	MinBPM float64 // нижняя граница пульса, уд/мин.
+	MaxBPM float64 // верхняя граница пульса, уд/мин.
+}
+
+// heartRateZoneBounds задает границы пяти зон пульса в долях от максимального пульса:
+// 50-60%, 60-70%, 70-80%, 80-90%, 90-100%.
+var heartRateZoneBounds = [6]float64{0.5, 0.6, 0.7, 0.8, 0.9, 1.0}
+
+// HeartRateZones возвращает пять зон пульса (Z1-Z5) относительно максимального пульса maxHR.
+//
+// Параметры:
+//
+// maxHR float64 — максимальный пульс пользователя, уд/мин. При отсутствии измеренного значения
+// можно использовать MaxHRByAge.
+func HeartRateZones(maxHR float64) [5]Zone {
+	var zones [5]Zone
+	for i := range zones {
+		zones[i] = Zone{
+			Name:   fmt.Sprintf("Z%d", i+1),
+			MinBPM: maxHR * heartRateZoneBounds[i],
+			MaxBPM: maxHR * heartRateZoneBounds[i+1],
+		}
+	}
+	return zones
+}
+
+// MaxHRByAge возвращает максимальный пульс по умолчанию, рассчитанный по формуле 220 - возраст.
+func MaxHRByAge(age float64) float64 {
+	return 220 - age
+}
+
+// TimeInZones распределяет показания пульса по пяти зонам интенсивности и возвращает суммарное
+// время, проведенное в каждой зоне. Время между двумя соседними отсчетами целиком относится к
+// зоне, в которую попадает более ранний из них.
+func TimeInZones(samples []HRSample, zones [5]Zone) [5]time.Duration {
+	var totals [5]time.Duration
+	for i := 0; i+1 < len(samples); i++ {
+		delta := samples[i+1].Time.Sub(samples[i].Time)
+		if idx := zoneIndex(samples[i].BPM, zones); idx >= 0 {
+			totals[idx] += delta
+		}
+	}
+	return totals
+}
+
+// zoneIndex возвращает индекс зоны, в которую попадает заданный пульс, либо -1, если пульс ниже
+// границы Z1.
+func zoneIndex(bpm float64, zones [5]Zone) int {
+	if bpm < zones[0].MinBPM {
+		return -1
+	}
+	for i, z := range zones {
+		if bpm <= z.MaxBPM {
+			return i
+		}
+	}
+	return len(zones) - 1
+}
+
+// Коэффициенты уравнения Кейтел для расчета калорий по частоте пульса.
+const (
+	keytelMaleConst        = -55.0969
+	keytelMaleHRCoef       = 0.6309
+	keytelMaleWeightCoef   = 0.1988
+	keytelMaleAgeCoef      = 0.2017
+	keytelFemaleConst      = -20.4022
+	keytelFemaleHRCoef     = 0.4472
+	keytelFemaleWeightCoef = 0.1263
+	keytelFemaleAgeCoef    = 0.0740
+	keytelKcalPerKJ        = 4.184 // перевод килоджоулей в килокалории.
+)
+
+// HRSpentCal возвращает количество калорий, затраченных за тренировку, по уравнению Кейтел,
+// которое использует среднюю частоту пульса вместо скорости движения и применимо к любому виду
+// активности.
+//
+// Параметры:
+//
+// durationMin float64 — длительность тренировки в минутах.
+// weight float64 — вес пользователя, кг.
+// age float64 — возраст пользователя, лет.
+// avgHR float64 — средний пульс за тренировку, уд/мин.
+// male bool — пол пользователя (true — мужской, false — женский).
+func HRSpentCal(durationMin, weight, age, avgHR float64, male bool) float64 {
+	var kcalPerMin float64
+	if male {
+		kcalPerMin = (keytelMaleConst + keytelMaleHRCoef*avgHR + keytelMaleWeightCoef*weight + keytelMaleAgeCoef*age) / keytelKcalPerKJ
+	} else {
+		kcalPerMin = (keytelFemaleConst + keytelFemaleHRCoef*avgHR - keytelFemaleWeightCoef*weight + keytelFemaleAgeCoef*age) / keytelKcalPerKJ
+	}
+	return kcalPerMin * durationMin
+}
+
+// hrStats возвращает средний, максимальный и минимальный пульс по набору отсчетов.
+func hrStats(samples []HRSample) (avg, min, max float64) {
+	min, max = samples[0].BPM, samples[0].BPM
+	var sum float64
+	for _, s := range samples {
+		sum += s.BPM
+		if s.BPM < min {
+			min = s.BPM
+		}
+		if s.BPM > max {
+			max = s.BPM
+		}
+	}
+	return sum / float64(len(samples)), min, max
+}
+
+// ShowHRBlock возвращает фрагмент с информацией о пульсе тренировки: средний, максимальный и
+// минимальный пульс, а также время, проведенное в каждой из пяти зон интенсивности. Возвращает
+// пустую строку, если показаний пульса нет.
+//
+// Параметры:
+//
+// samples []HRSample — показания пульса за тренировку.
+// maxHR float64 — максимальный пульс пользователя для расчета границ зон.
+func ShowHRBlock(samples []HRSample, maxHR float64) string {
+	if len(samples) == 0 {
+		return ""
+	}
+
+	avg, min, max := hrStats(samples)
+	zones := HeartRateZones(maxHR)
+	timeInZones := TimeInZones(samples, zones)
+
+	block := fmt.Sprintf(`Пульс: средний %.0f, макс %.0f, мин %.0f уд/мин
+`, avg, max, min)
+	for i, z := range zones {
+		block += fmt.Sprintf("%s (%.0f-%.0f уд/мин): %s\n", z.Name, z.MinBPM, z.MaxBPM, timeInZones[i].Round(time.Second))
+	}
+	return block
+}
+
+// ShowTrainInfoWithHR возвращает строку с информацией о тренировке, дополненную блоком о пульсе,
+// если переданы показания пульса. Если samples пуст, результат совпадает с ShowTrainInfo.
+//
+// Параметры:
+//
+// training Training — тренировка, информацию о которой нужно показать.
+// samples []HRSample — показания пульса за тренировку, могут быть пустыми.
+// maxHR float64 — максимальный пульс пользователя для расчета границ зон (см. MaxHRByAge).
+func ShowTrainInfoWithHR(training Training, samples []HRSample, maxHR float64) string {
+	return training.Info() + ShowHRBlock(samples, maxHR)
+}
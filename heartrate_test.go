@@ -0,0 +1,45 @@
+package ftracker
+
+import "testing"
+
+func TestHRSpentCal(t *testing.T) {
+	tests := []struct {
+		name               string
+		durationMin        float64
+		weight, age, avgHR float64
+		male               bool
+		want               float64
+	}{
+		{"мужчина", 30, 80, 30, 140, true, 395.679},
+		{"женщина", 30, 60, 30, 140, false, 264.205},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HRSpentCal(tt.durationMin, tt.weight, tt.age, tt.avgHR, tt.male)
+			if diff := got - tt.want; diff > 0.01 || diff < -0.01 {
+				t.Errorf("HRSpentCal(%v, %v, %v, %v, %v) = %.3f, хотим %.3f", tt.durationMin, tt.weight, tt.age, tt.avgHR, tt.male, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHRSpentCal_FemaleWeightReducesCalories(t *testing.T) {
+	lighter := HRSpentCal(30, 55, 30, 140, false)
+	heavier := HRSpentCal(30, 85, 30, 140, false)
+
+	if heavier >= lighter {
+		t.Errorf("по уравнению Кейтел у женщин калории должны падать с ростом веса: lighter=%.3f heavier=%.3f", lighter, heavier)
+	}
+}
+
+func TestHeartRateZones(t *testing.T) {
+	zones := HeartRateZones(200)
+
+	if zones[0].MinBPM != 100 || zones[0].MaxBPM != 120 {
+		t.Errorf("Z1 = [%v, %v], хотим [100, 120]", zones[0].MinBPM, zones[0].MaxBPM)
+	}
+	if zones[4].MinBPM != 180 || zones[4].MaxBPM != 200 {
+		t.Errorf("Z5 = [%v, %v], хотим [180, 200]", zones[4].MinBPM, zones[4].MaxBPM)
+	}
+}
@@ -0,0 +1,99 @@
+package ftracker
+
+import (
+	"fmt"
+	"time"
+)
+
+// Session описывает одну завершенную тренировку в виде, удобном для агрегации статистики по
+// нескольким тренировкам сразу. В отличие от Training, который умеет считать метрики по сырым
+// данным (шагам, гребкам), Session уже содержит готовые значения — их можно получить из Training
+// (Distance, MeanSpeed, Calories) или взять из трекера устройства.
+type Session struct {
+	Sport    string    // вид тренировки (как в ShowTrainInfo: "Бег", "Ходьба", "Плавание" и т.п.).
+	Start    time.Time // время начала тренировки.
+	Distance float64   // дистанция в километрах.
+	Duration float64   // длительность в часах.
+	Calories float64   // затраченные калории.
+	Ascent   float64   // суммарный набор высоты за тренировку, в метрах.
+	Descent  float64   // суммарная потеря высоты за тренировку, в метрах.
+}
+
+// GroupBy задает, по какому периоду времени группировать тренировки при агрегации.
+type GroupBy int
+
+// Поддерживаемые варианты группировки. GroupBySport не делит тренировки по времени — все они
+// попадают в единственный период.
+const (
+	GroupBySport GroupBy = iota
+	GroupByDay
+	GroupByWeek
+	GroupByMonth
+	GroupByYear
+)
+
+// allPeriod — ключ периода, используемый при группировке GroupBySport, где разбивки по времени нет.
+const allPeriod = "all"
+
+// periodKey возвращает ключ периода для времени начала тренировки согласно выбранной группировке.
+func periodKey(start time.Time, groupBy GroupBy) string {
+	switch groupBy {
+	case GroupByDay:
+		return start.Format("2006-01-02")
+	case GroupByWeek:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case GroupByMonth:
+		return start.Format("2006-01")
+	case GroupByYear:
+		return start.Format("2006")
+	default:
+		return allPeriod
+	}
+}
+
+// SportStats — агрегированная статистика по одному виду спорта за один период.
+type SportStats struct {
+	NbWorkouts    int     // количество тренировок.
+	TotalDistance float64 // суммарная дистанция, км.
+	TotalDuration float64 // суммарная длительность, часы.
+	TotalCalories float64 // суммарно затраченные калории.
+	TotalAscent   float64 // суммарный набор высоты, метры.
+	TotalDescent  float64 // суммарная потеря высоты, метры.
+}
+
+// Stats — результат Aggregate: для каждого периода (ключ верхнего уровня; "all", если
+// группировка была только по виду спорта) хранит статистику по каждому встретившемуся виду
+// спорта.
+type Stats map[string]map[string]SportStats
+
+// Aggregate считает суммарную статистику по переданным тренировкам, сгруппированную по виду
+// спорта и, опционально, по периоду времени начала тренировки.
+//
+// Параметры:
+//
+// sessions []Session — тренировки, которые нужно агрегировать.
+// groupBy GroupBy — по какому периоду группировать (день/неделя/месяц/год), либо GroupBySport,
+// чтобы не делить тренировки по времени вовсе.
+func Aggregate(sessions []Session, groupBy GroupBy) Stats {
+	stats := make(Stats)
+	for _, s := range sessions {
+		period := periodKey(s.Start, groupBy)
+
+		bySport, ok := stats[period]
+		if !ok {
+			bySport = make(map[string]SportStats)
+			stats[period] = bySport
+		}
+
+		cur := bySport[s.Sport]
+		cur.NbWorkouts++
+		cur.TotalDistance += s.Distance
+		cur.TotalDuration += s.Duration
+		cur.TotalCalories += s.Calories
+		cur.TotalAscent += s.Ascent
+		cur.TotalDescent += s.Descent
+		bySport[s.Sport] = cur
+	}
+	return stats
+}
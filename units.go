@@ -0,0 +1,151 @@
+package ftracker
+
+import (
+	"fmt"
+	"math"
+)
+
+// UnitSystem описывает, в каких единицах измерения показывать пользователю результаты
+// тренировки. Сами формулы (дистанция по шагам, калории по скорости или MET) всегда считаются
+// во внутренних метрических единицах — UnitSystem отвечает только за то, как перевести и
+// подписать итоговые значения при выводе.
+type UnitSystem struct {
+	Name         string  // название системы, для отображения.
+	DistanceUnit string  // подпись единицы дистанции: "км" или "mi".
+	SpeedUnit    string  // подпись единицы скорости: "км/ч" или "mph".
+	HeightUnit   string  // подпись единицы роста: "см" или "ft".
+	WeightUnit   string  // подпись единицы веса: "кг" или "lb".
+	KmToUnit     float64 // множитель для перевода километров в единицу дистанции.
+	KmhToUnit    float64 // множитель для перевода км/ч в единицу скорости.
+	CmToUnit     float64 // множитель для перевода сантиметров в единицу роста.
+	KgToUnit     float64 // множитель для перевода килограммов в единицу веса.
+}
+
+// Metric и Imperial — готовые системы единиц для ShowTrainInfo через Tracker.
+var (
+	// Metric — метрическая система, совпадает с выводом ShowTrainInfo по умолчанию.
+	Metric = UnitSystem{
+		Name:         "метрическая",
+		DistanceUnit: "км",
+		SpeedUnit:    "км/ч",
+		HeightUnit:   "см",
+		WeightUnit:   "кг",
+		KmToUnit:     1,
+		KmhToUnit:    1,
+		CmToUnit:     1,
+		KgToUnit:     1,
+	}
+
+	// Imperial — имперская система: мили, мили в час, футы, фунты.
+	Imperial = UnitSystem{
+		Name:         "имперская",
+		DistanceUnit: "mi",
+		SpeedUnit:    "mph",
+		HeightUnit:   "ft",
+		WeightUnit:   "lb",
+		KmToUnit:     0.621371,
+		KmhToUnit:    0.621371,
+		CmToUnit:     0.0328084,
+		KgToUnit:     2.20462,
+	}
+)
+
+// Tracker рассчитывает дистанцию и темп, а также показывает информацию о тренировках в заданной
+// системе единиц. Нулевое значение Tracker соответствует метрической системе.
+type Tracker struct {
+	Units UnitSystem
+}
+
+// units возвращает систему единиц трекера, подставляя Metric для нулевого значения.
+func (t Tracker) units() UnitSystem {
+	if t.Units == (UnitSystem{}) {
+		return Metric
+	}
+	return t.Units
+}
+
+// Distance возвращает дистанцию, пройденную за action действий, в единицах трекера.
+func (t Tracker) Distance(action int) float64 {
+	return Distance(action) * t.units().KmToUnit
+}
+
+// MeanSpeed возвращает среднюю скорость движения во время тренировки в единицах трекера.
+func (t Tracker) MeanSpeed(action int, duration float64) float64 {
+	return MeanSpeed(action, duration) * t.units().KmhToUnit
+}
+
+// Pace возвращает темп тренировки в формате "мм:сс на км" или "мм:сс на милю" в зависимости от
+// системы единиц трекера.
+//
+// Параметры:
+//
+// distanceKm float64 — дистанция в километрах.
+// duration float64 — длительность тренировки в часах.
+func (t Tracker) Pace(distanceKm, duration float64) string {
+	u := t.units()
+	distance := distanceKm * u.KmToUnit
+
+	mmss := "00:00"
+	if distance != 0 {
+		total := int(math.Round(duration * 3600 / distance))
+		mmss = fmt.Sprintf("%02d:%02d", total/60, total%60)
+	}
+	if u.DistanceUnit == "mi" {
+		return mmss + " на милю"
+	}
+	return mmss + " на км"
+}
+
+// ShowTrainInfo возвращает строку с информацией о тренировке в системе единиц трекера: для
+// метрической системы результат совпадает с training.Info(), для имперской — те же поля, но
+// дистанция и скорость переводятся в мили/mph (а для силовой тренировки — тоннаж в фунты).
+// ShowTrainInfo никогда не добавляет поля, которых нет в training.Info() для метрической системы.
+func (t Tracker) ShowTrainInfo(training Training) string {
+	u := t.units()
+	if u == Metric {
+		return training.Info()
+	}
+	return formatInfoInUnits(training, u)
+}
+
+// formatInfoInUnits перерисовывает информацию о тренировке в системе единиц u, сохраняя тот же
+// набор полей, что и training.Info(). Для любого типа тренировки, не перечисленного ниже,
+// возвращается обычный training.Info().
+func formatInfoInUnits(training Training, u UnitSystem) string {
+	switch t := training.(type) {
+	case RunningTraining:
+		return showLocomotionInfo("Бег", t.Duration, t.Distance(), t.MeanSpeed(), t.Calories(), u)
+	case WalkingTraining:
+		return showLocomotionInfo("Ходьба", t.Duration, t.Distance(), t.MeanSpeed(), t.Calories(), u)
+	case SwimmingTraining:
+		return showLocomotionInfo("Плавание", t.Duration, t.Distance(), t.MeanSpeed(), t.Calories(), u)
+	case StrengthTraining:
+		return fmt.Sprintf(`Тип тренировки: Силовая тренировка
+Длительность: %.2f ч.
+Подходы: %d
+Повторения: %d
+Тоннаж: %.2f %s
+Сожгли калорий: %.2f
+`, t.Duration, t.Sets, t.Reps, t.Volume()*u.KgToUnit, u.WeightUnit, t.Calories())
+	case RepetitionTraining:
+		// Info() тренировки с собственным весом не показывает ни дистанцию, ни вес, поэтому
+		// переводить здесь нечего — но случай выделен явно, а не оставлен на default, чтобы
+		// не потерять его при будущих изменениях формата.
+		return t.Info()
+	default:
+		return training.Info()
+	}
+}
+
+// showLocomotionInfo форматирует информацию о тренировке, связанной с перемещением в
+// пространстве (бег, ходьба, плавание), переводя дистанцию и скорость в систему единиц u. Набор
+// полей в точности совпадает с соответствующим Info() — весь и рост там не показываются, поэтому
+// и здесь их нет.
+func showLocomotionInfo(sport string, duration, distanceKm, speedKmh, calories float64, u UnitSystem) string {
+	return fmt.Sprintf(`Тип тренировки: %s
+Длительность: %.2f ч.
+Дистанция: %.2f %s.
+Скорость: %.2f %s
+Сожгли калорий: %.2f
+`, sport, duration, distanceKm*u.KmToUnit, u.DistanceUnit, speedKmh*u.KmhToUnit, u.SpeedUnit, calories)
+}